@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy controls what happens when an AsyncSink's buffer is full and a new
+// message arrives while the background writer is still catching up.
+type OverflowPolicy int
+
+const (
+	// DropOldest discards the oldest buffered message to make room for the new one.
+	DropOldest OverflowPolicy = iota
+
+	// DropNewest discards the incoming message, leaving the buffer untouched.
+	DropNewest
+
+	// Block waits for room in the buffer, applying backpressure to the caller.
+	Block
+)
+
+// AsyncOptions configures an AsyncSink.
+type AsyncOptions struct {
+	// BufferSize is the number of messages the buffer can hold before Overflow
+	// kicks in. Defaults to 1024 if <= 0.
+	BufferSize int
+
+	// FlushInterval is how often the background goroutine flushes its bufio.Writer,
+	// independent of whether the buffer is full. Defaults to one second if <= 0.
+	FlushInterval time.Duration
+
+	// Overflow selects the behavior once BufferSize is reached. Defaults to
+	// DropOldest.
+	Overflow OverflowPolicy
+}
+
+// AsyncSink wraps an io.Writer so that WriteMsg enqueues records into a bounded
+// buffer instead of blocking the caller on I/O. A background goroutine drains the
+// buffer through a bufio.Writer, flushing on FlushInterval or when asked to via
+// Flush, the same batching model hclog and klog use to keep logging off the hot path.
+//
+// WriteMsg renders its message synchronously, before enqueueing, rather than handing
+// the LogMessage itself to the background goroutine. msg.render() shares a
+// renderCache across every sink a message is fanned out to (see sink.go), and that
+// cache has no locking of its own — it relies on all sinks rendering on the same,
+// single calling goroutine. Deferring the render to the background goroutine would
+// race with any other sink (synchronous or async) rendering the same message
+// concurrently.
+type AsyncSink struct {
+	level int
+	json  bool
+	opts  AsyncOptions
+
+	queue chan string
+
+	mu  sync.Mutex // guards buf, shared between the background goroutine and Flush/Close
+	buf *bufio.Writer
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewAsyncSink starts an AsyncSink writing to w at the given level and format. The
+// background goroutine runs until Close is called.
+func NewAsyncSink(w io.Writer, level int, json bool, opts AsyncOptions) *AsyncSink {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+
+	s := &AsyncSink{
+		level: level,
+		json:  json,
+		opts:  opts,
+		queue: make(chan string, opts.BufferSize),
+		buf:   bufio.NewWriter(w),
+		done:  make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.run()
+
+	return s
+}
+
+// WriteMsg renders msg on the calling goroutine, then enqueues the rendered record
+// for the background goroutine to write, applying the sink's Overflow policy if the
+// buffer is full. Rendering happens here rather than in the background goroutine so
+// it can still safely share msg's renderCache with any other sink msg is fanned out
+// to. WriteMsg otherwise performs no I/O, so it returns almost immediately regardless
+// of how slow the underlying writer is.
+func (s *AsyncSink) WriteMsg(msg LogMessage) error {
+	if msg.Level < s.level {
+		return nil
+	}
+
+	record, err := msg.render(s.json)
+	if err != nil {
+		return fmt.Errorf("error formatting log message: %w", err)
+	}
+
+	select {
+	case s.queue <- record:
+		return nil
+	default:
+	}
+
+	switch s.opts.Overflow {
+	case Block:
+		select {
+		case s.queue <- record:
+			return nil
+		case <-s.done:
+			return fmt.Errorf("async sink is closed")
+		}
+	case DropNewest:
+		return nil
+	default: // DropOldest
+		select {
+		case <-s.queue:
+		default:
+		}
+		select {
+		case s.queue <- record:
+		default:
+			// Another goroutine refilled the slot first; drop record rather than block.
+		}
+		return nil
+	}
+}
+
+// run drains the queue, writing each record through buf and flushing on
+// FlushInterval. It exits once done is closed, after draining whatever is left.
+func (s *AsyncSink) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case record := <-s.queue:
+			s.write(record)
+		case <-ticker.C:
+			_ = s.Flush()
+		case <-s.done:
+			s.drainQueued()
+			return
+		}
+	}
+}
+
+// drainQueued writes every record currently sitting in the queue, without blocking
+// for more to arrive. Both Close and Flush call this before flushing buf, since a
+// record that's been queued but not yet written wouldn't otherwise survive either.
+func (s *AsyncSink) drainQueued() {
+	for {
+		select {
+		case record := <-s.queue:
+			s.write(record)
+		default:
+			return
+		}
+	}
+}
+
+// write appends record to the buffered writer.
+func (s *AsyncSink) write(record string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.buf.WriteString(record); err != nil {
+		fmt.Fprintf(os.Stderr, "Logger error: error writing log message: %s\n", err)
+	}
+}
+
+// Flush drains any records still sitting in the queue and forces the buffered
+// writer to write everything through to the underlying writer. Logger calls this on
+// every sink that implements it before a FATAL message exits the process, so a
+// crash never loses a message that was queued but not yet written.
+func (s *AsyncSink) Flush() error {
+	s.drainQueued()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Flush()
+}
+
+// Close stops the background goroutine, draining and flushing any remaining
+// records before returning.
+func (s *AsyncSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return s.Flush()
+}
+
+// NewAsync creates a Logger whose single sink writes asynchronously: Log enqueues
+// records into a bounded buffer instead of blocking on I/O, and a background
+// goroutine batches them through a bufio.Writer. Fatal still flushes synchronously
+// before exiting, so a FATAL message is never lost to an unflushed buffer.
+//
+// Parameters:
+//
+//	level (int): The minimum log level for messages to be logged.
+//	w (io.Writer): The underlying writer the background goroutine writes to.
+//	json (bool): A boolean flag indicating the desired output format.
+//	opts (AsyncOptions): Buffer size, flush interval, and overflow policy.
+//
+// Returns:
+//
+//	*Logger: A pointer to the newly created Logger instance.
+func NewAsync(level int, w io.Writer, json bool, opts AsyncOptions) *Logger {
+	return NewWithSinks(level, NewAsyncSink(w, level, json, opts))
+}
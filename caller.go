@@ -0,0 +1,26 @@
+package logger
+
+import "runtime"
+
+// callerSkip is how many frames captureCaller must ascend from inside itself to
+// reach the user's own call site. Every public logging method (Log, Debug, Info,
+// Warning, Error, Fatal, and their *W variants) calls Logger.output directly rather
+// than through one another, so the stack between the user and here is always:
+// captureCaller -> output -> the public method -> the user. That's 3 frames above
+// captureCaller's own, per runtime.Caller's "0 is the caller of Caller" convention.
+const callerSkip = 3
+
+// captureCaller returns the file, line, and function name of the goroutine's stack
+// frame skip levels up, or zeroed fields if the information isn't available.
+func captureCaller(skip int) (file string, line int, fn string) {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "", 0, ""
+	}
+
+	if f := runtime.FuncForPC(pc); f != nil {
+		fn = f.Name()
+	}
+
+	return file, line, fn
+}
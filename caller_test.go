@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithCallerInfoPopulatesFileLineFunc(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true).WithCallerInfo(true)
+
+	l.Info("hello")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+
+	file, _ := out["file"].(string)
+	if !strings.HasSuffix(file, "caller_test.go") {
+		t.Errorf("expected file to be this test file, got %v", out["file"])
+	}
+	if _, ok := out["line"]; !ok {
+		t.Errorf("expected line to be populated, got %v", out)
+	}
+	fn, _ := out["func"].(string)
+	if !strings.Contains(fn, "TestWithCallerInfoPopulatesFileLineFunc") {
+		t.Errorf("expected func to name this test, got %v", out["func"])
+	}
+}
+
+func TestWithoutCallerInfoOmitsFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	l.Info("hello")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+
+	if _, ok := out["file"]; ok {
+		t.Errorf("expected file to be omitted when WithCallerInfo is not enabled, got %v", out)
+	}
+}
+
+func TestStackCapturedOnErrorNotOnInfo(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	l.Info("info message")
+	var info map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &info); err != nil {
+		t.Fatalf("failed to unmarshal INFO log line: %v", err)
+	}
+	if _, ok := info["stack"]; ok {
+		t.Errorf("expected no stack on an INFO message, got %v", info)
+	}
+
+	buf.Reset()
+	l.Error("error message")
+	var errOut map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &errOut); err != nil {
+		t.Fatalf("failed to unmarshal ERROR log line: %v", err)
+	}
+	stack, _ := errOut["stack"].(string)
+	if !strings.Contains(stack, "goroutine") {
+		t.Errorf("expected a captured stack trace on an ERROR message, got %v", errOut["stack"])
+	}
+}
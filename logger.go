@@ -1,11 +1,11 @@
 package logger
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"sync"
+	"runtime/debug"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,25 +23,70 @@ type LogMessage struct {
 	Level int       `json:"level"`
 	Msg   string    `json:"msg"`
 	Time  time.Time `json:"time"`
+
+	// Fields carries the structured key/value pairs attached to this entry, merged from
+	// any child logger created with With and the fields passed at the call site.
+	// It is rendered manually by FormatJSON/FormatText rather than through the default
+	// struct tags, since each format flattens it differently.
+	Fields map[string]any `json:"-"`
+
+	// File, Line, and Func identify the call site that logged this entry. They're
+	// only populated when the logger was built with WithCallerInfo(true), since
+	// runtime.Caller isn't free.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+	Func string `json:"func,omitempty"`
+
+	// Stack holds a runtime/debug.Stack() snapshot, captured automatically for
+	// ERROR and FATAL entries regardless of the WithCallerInfo setting.
+	Stack string `json:"stack,omitempty"`
+
+	// cache memoizes the rendered form of this message across the sinks it's fanned
+	// out to, so that N sinks sharing a format trigger one render instead of N.
+	cache *renderCache
 }
 
 // Logger struct encapsulates the logging functionality
 type Logger struct {
-	// Minimum log level for logging messages
-	level int
+	// level is the minimum log level for logging messages; messages below it are
+	// dropped before reaching any sink. It's a pointer so SetLevel is visible to
+	// every child created via With and can be read in the hot path of output via
+	// atomic.LoadInt32 instead of a mutex.
+	level *int32
+
+	// sinks are the destinations a record is fanned out to. Each sink is responsible
+	// for its own minimum level and output format.
+	sinks []Sink
+
+	// fields holds the structured key/value pairs attached by With. It is never
+	// mutated after creation: With always produces a new map, so a Logger can be
+	// shared and read across goroutines without locking.
+	fields map[string]any
 
-	// Interface for writing log outputs
-	writer io.Writer
+	// redactors is shared with every child created via With, so a RegisterRedactor
+	// call on a parent also applies to children already handed out.
+	redactors *redactorRegistry
 
-	// Flag to determine if logs should be in JSON format
-	// true => output JSON; false => output text
-	json bool
+	// callerInfo enables capturing the call site (file/line/func) of every log
+	// entry. Off by default since runtime.Caller isn't free; see WithCallerInfo.
+	callerInfo bool
 
-	// Mutex for thread-safe log writing
-	mu sync.Mutex
+	// verbosity is the global klog-style verbosity threshold used by V when no
+	// vmodule override matches the caller's file. Shared and atomically accessed
+	// for the same reasons as level.
+	verbosity *int32
+
+	// vmodule holds any per-file verbosity overrides configured via SetVmodule. A nil
+	// Load() means no overrides are configured. It's an atomic.Pointer rather than a
+	// bare *vmoduleConfig, shared by pointer with every child created via With, for
+	// the same reason level and verbosity are atomic: SetVmodule is meant to support
+	// reconfiguring trace verbosity at runtime, concurrently with V being called on
+	// the hot path from other goroutines.
+	vmodule *atomic.Pointer[vmoduleConfig]
 }
 
 // New creates a new Logger instance with the specified logging level, output writer, and format.
+// It is a convenience wrapper around NewWithSinks for the common single-destination case.
 //
 // Parameters:
 //
@@ -57,73 +102,151 @@ type Logger struct {
 //
 //	*Logger: A pointer to the newly created Logger instance
 func New(level int, writer io.Writer, json bool) *Logger {
+	return NewWithSinks(level, NewWriterSink(writer, level, json))
+}
+
+// NewWithSinks creates a Logger that fans every record out to sinks. This is the
+// primary constructor; New is a thin convenience wrapper around a single WriterSink.
+//
+// Parameters:
+//
+//	level (int): The logger's own minimum level. Messages below it are dropped before
+//	             reaching any sink, so a sink's own level can only raise the bar further.
+//	sinks (...Sink): The destinations to fan log entries out to, each with its own
+//	                 minimum level and format.
+//
+// Returns:
+//
+//	*Logger: A pointer to the newly created Logger instance.
+func NewWithSinks(level int, sinks ...Sink) *Logger {
+	lvl := int32(level)
+	verbosity := int32(0)
+
 	return &Logger{
-		level:  level,
-		writer: writer,
-		json:   json,
+		level:     &lvl,
+		sinks:     sinks,
+		redactors: &redactorRegistry{},
+		verbosity: &verbosity,
+		vmodule:   &atomic.Pointer[vmoduleConfig]{},
 	}
 }
 
-// formatJSON formats the given log message as a JSON string.
-// It marshals the LogMessage struct into JSON format, returning the string representation.
-// If an error occurs during marshaling, it returns an error.
-func (l *Logger) formatJSON(logMessage LogMessage) (string, error) {
-	jsonData, err := json.Marshal(logMessage)
-	if err != nil {
-		return "", fmt.Errorf("error marshaling log message: %w", err)
+// SetLevel atomically sets the logger's minimum level at runtime. It's visible
+// immediately to every child logger created via With, since they share the same
+// underlying level.
+func (l *Logger) SetLevel(level int) {
+	atomic.StoreInt32(l.level, int32(level))
+}
+
+// GetLevel atomically returns the logger's current minimum level.
+func (l *Logger) GetLevel() int {
+	return int(atomic.LoadInt32(l.level))
+}
+
+// With returns a child logger that fans out to the same sinks but carries its own
+// immutable set of structured fields. The returned logger's fields are a
+// copy-on-write merge of the parent's fields and the ones passed in, so mutating the
+// map passed to With, or calling With again on the parent, never affects loggers
+// already handed out.
+//
+// Parameters:
+//
+//	fields (map[string]any): The fields to attach to every message logged through
+//	                          the returned logger. Keys already present on the
+//	                          parent are overridden.
+//
+// Returns:
+//
+//	*Logger: A new Logger sharing the sinks of the receiver.
+func (l *Logger) With(fields map[string]any) *Logger {
+	return &Logger{
+		level:      l.level,
+		sinks:      l.sinks,
+		fields:     mergeFields(l.fields, fields),
+		redactors:  l.redactors,
+		callerInfo: l.callerInfo,
+		verbosity:  l.verbosity,
+		vmodule:    l.vmodule,
 	}
-	return string(jsonData) + "\n", nil
 }
 
-// formatText formats the log message as a plain text string.
-// The format includes the timestamp, log level, and the log message content.
-// The timestamp is formatted using the RFC3339 standard. The log level is converted to a string.
-func (l *Logger) formatText(logMessage LogMessage) string {
-	return fmt.Sprintf("%s [%s] %s\n", // TODO: add opts for date format
-		logMessage.Time.Format(time.RFC3339),
-		GetLevelString(logMessage.Level),
-		logMessage.Msg,
-	)
+// WithCallerInfo returns a child logger identical to the receiver except that
+// capturing the call site (file/line/func) of every entry is enabled or disabled.
+// It's opt-in and off by default because runtime.Caller isn't free — matching
+// hclog's IncludeLocation option.
+//
+// Parameters:
+//
+//	enabled (bool): Whether to capture caller info on every subsequent entry.
+//
+// Returns:
+//
+//	*Logger: A new Logger sharing the sinks, fields, and redactors of the receiver.
+func (l *Logger) WithCallerInfo(enabled bool) *Logger {
+	child := *l
+	child.callerInfo = enabled
+	return &child
+}
+
+// mergeFields returns a new map containing base overlaid with override, so that keys
+// in override take precedence. It returns nil if both maps are empty, so a LogMessage
+// with no fields omits them entirely from the rendered output.
+func mergeFields(base, override map[string]any) map[string]any {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]any, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+
+	return merged
 }
 
 // Log checks the log message level and processes it accordingly.
 // If the log level is below the logger's set level, the message is ignored.
-// If the message meets the required log level, it is either formatted as JSON or plain text
-// based on the logger's configuration, then written to the output.
+// Otherwise the message is fanned out to every sink, each of which formats and
+// filters it independently.
 // In case of a FATAL log level, the program is terminated after the message is logged.
 func (l *Logger) Log(level int, message string) {
-	if level < l.level {
+	l.output(level, message, nil)
+}
+
+// output is the shared implementation behind every public logging method (Log,
+// Debug/Info/Warning/Error/Fatal and their *W variants). Every one of those calls
+// output directly, never through one another, so the stack is always exactly one
+// frame deep between the user's call site and output — which is what lets
+// captureCaller use a single constant skip count regardless of which method was called.
+func (l *Logger) output(level int, message string, fields map[string]any) {
+	if level < l.GetLevel() {
 		// Ignore messages that are below the current log level
 		return
 	}
 
-	logMessage := LogMessage{
-		Level: level,
-		Msg:   message,
-		Time:  time.Now(),
-	}
-
-	var logRecord string
-	var err error
+	logMessage := l.redactors.apply(LogMessage{
+		Level:  level,
+		Msg:    message,
+		Time:   time.Now(),
+		Fields: mergeFields(l.fields, fields),
+	})
 
-	// Format the log message as JSON or plain text depending on the logger's configuration
-	if l.json {
-		logRecord, err = l.formatJSON(logMessage)
-		if err != nil {
-			l.logError(fmt.Errorf("error formatting log message as JSON: %w", err))
-			return
-		}
-	} else {
-		logRecord = l.formatText(logMessage)
+	if l.callerInfo {
+		logMessage.File, logMessage.Line, logMessage.Func = captureCaller(callerSkip)
+	}
+	if level >= ERROR {
+		logMessage.Stack = string(debug.Stack())
 	}
 
-	// Creates and writes a log message with thread-safe synchronization
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	logMessage.cache = &renderCache{}
 
-	// Write the formatted log message to the specified output writer
-	if _, err = fmt.Fprint(l.writer, logRecord); err != nil {
-		l.logError(fmt.Errorf("error writing log message: %w", err))
+	for _, sink := range l.sinks {
+		if err := sink.WriteMsg(logMessage); err != nil {
+			l.logError(fmt.Errorf("error writing to sink: %w", err))
+		}
 	}
 }
 
@@ -137,7 +260,18 @@ func (l *Logger) Log(level int, message string) {
 //
 //	message (string): The log message to be recorded.
 func (l *Logger) Debug(message string) {
-	l.Log(DEBUG, message)
+	l.output(DEBUG, message, nil)
+}
+
+// DebugW logs a message at the DEBUG level with additional structured fields,
+// merged on top of any fields attached via With.
+//
+// Parameters:
+//
+//	message (string): The log message to be recorded.
+//	fields (map[string]any): Structured fields to attach to this entry.
+func (l *Logger) DebugW(message string, fields map[string]any) {
+	l.output(DEBUG, message, fields)
 }
 
 // Info logs a message at the INFO level.
@@ -150,7 +284,18 @@ func (l *Logger) Debug(message string) {
 //
 //	message (string): The log message to be recorded.
 func (l *Logger) Info(message string) {
-	l.Log(INFO, message)
+	l.output(INFO, message, nil)
+}
+
+// InfoW logs a message at the INFO level with additional structured fields,
+// merged on top of any fields attached via With.
+//
+// Parameters:
+//
+//	message (string): The log message to be recorded.
+//	fields (map[string]any): Structured fields to attach to this entry.
+func (l *Logger) InfoW(message string, fields map[string]any) {
+	l.output(INFO, message, fields)
 }
 
 // Warning logs a message at the WARNING level.
@@ -163,7 +308,18 @@ func (l *Logger) Info(message string) {
 //
 //	message (string): The log message to be recorded.
 func (l *Logger) Warning(message string) {
-	l.Log(WARNING, message)
+	l.output(WARNING, message, nil)
+}
+
+// WarningW logs a message at the WARNING level with additional structured fields,
+// merged on top of any fields attached via With.
+//
+// Parameters:
+//
+//	message (string): The log message to be recorded.
+//	fields (map[string]any): Structured fields to attach to this entry.
+func (l *Logger) WarningW(message string, fields map[string]any) {
+	l.output(WARNING, message, fields)
 }
 
 // Error logs a message at the ERROR level.
@@ -176,7 +332,18 @@ func (l *Logger) Warning(message string) {
 //
 //	message (string): The log message to be recorded.
 func (l *Logger) Error(message string) {
-	l.Log(ERROR, message)
+	l.output(ERROR, message, nil)
+}
+
+// ErrorW logs a message at the ERROR level with additional structured fields,
+// merged on top of any fields attached via With.
+//
+// Parameters:
+//
+//	message (string): The log message to be recorded.
+//	fields (map[string]any): Structured fields to attach to this entry.
+func (l *Logger) ErrorW(message string, fields map[string]any) {
+	l.output(ERROR, message, fields)
 }
 
 // Fatal logs a message at the FATAL level and terminates the program.
@@ -189,26 +356,61 @@ func (l *Logger) Error(message string) {
 //	message (string): The log message to be recorded.
 func (l *Logger) Fatal(message string) {
 	// Log the message with the FATAL level.
-	l.Log(FATAL, message)
+	l.output(FATAL, message, nil)
+
+	// Flush any sinks that buffer output (e.g. AsyncSink) before exiting, so a FATAL
+	// message is never lost to an unflushed buffer.
+	l.flushSinks()
 
 	// Exit the program with a status of 1 to indicate a fatal error.
 	os.Exit(1)
 }
 
-// logError handles errors encountered during the logging process.
-// It constructs an error LogMessage and writes it to the specified writer.
-// If writing fails, it logs the error to stdout.
-func (l *Logger) logError(err error) {
-	errorLog := LogMessage{
-		Level: ERROR,
-		Msg:   err.Error(),
-		Time:  time.Now(),
+// FatalW logs a message at the FATAL level with additional structured fields, merged
+// on top of any fields attached via With, and then terminates the program.
+//
+// Parameters:
+//
+//	message (string): The log message to be recorded.
+//	fields (map[string]any): Structured fields to attach to this entry.
+func (l *Logger) FatalW(message string, fields map[string]any) {
+	l.output(FATAL, message, fields)
+	l.flushSinks()
+	os.Exit(1)
+}
+
+// flusher is implemented by sinks that buffer their output and need an explicit
+// flush, such as AsyncSink. Sinks that write synchronously don't need to implement it.
+type flusher interface {
+	Flush() error
+}
+
+// flushSinks flushes every sink that implements flusher.
+func (l *Logger) flushSinks() {
+	for _, sink := range l.sinks {
+		if f, ok := sink.(flusher); ok {
+			_ = f.Flush()
+		}
 	}
+}
 
-	// Write the error log to the writer, if it fails log to stdout
-	if _, writeErr := fmt.Fprintf(l.writer, "Logger error: %s\n", errorLog.Msg); writeErr != nil {
-		fmt.Printf("Error writing to log writer: %v\n", writeErr)
+// Close closes every sink the logger fans out to, flushing and releasing any
+// resources (open files, connections, pending batches) they hold.
+func (l *Logger) Close() error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+	return firstErr
+}
+
+// logError handles errors encountered while fanning a message out to its sinks.
+// It writes directly to stderr rather than back through the sinks, so a broken
+// sink can't swallow the report of its own failure.
+func (l *Logger) logError(err error) {
+	fmt.Fprintf(os.Stderr, "Logger error: %s\n", err.Error())
 }
 
 // GetLevelString converts a log level integer to its string representation.
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLogLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(WARNING, &buf, false)
+
+	l.Debug("debug message")
+	l.Info("info message")
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged below WARNING, got %q", buf.String())
+	}
+
+	l.Warning("warning message")
+	if !strings.Contains(buf.String(), "warning message") {
+		t.Fatalf("expected warning message to be logged, got %q", buf.String())
+	}
+}
+
+func TestWithFieldsMergeAndOverride(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	child := l.With(map[string]any{"service": "api", "env": "prod"})
+	child.InfoW("hello", map[string]any{"env": "staging"})
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+
+	if out["service"] != "api" {
+		t.Errorf("expected service=api, got %v", out["service"])
+	}
+	if out["env"] != "staging" {
+		t.Errorf("expected call-site field to override child field, got env=%v", out["env"])
+	}
+}
+
+func TestWithDoesNotMutateParent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	_ = l.With(map[string]any{"a": 1})
+	buf.Reset()
+
+	l.Info("no fields here")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+	if _, ok := out["a"]; ok {
+		t.Errorf("expected parent logger to be unaffected by With, got fields %v", out)
+	}
+}
+
+func TestSetLevelGetLevel(t *testing.T) {
+	// The sink itself has its own minimum level fixed at construction (see sink.go),
+	// so it's built at DEBUG here to isolate what SetLevel controls: the Logger's own
+	// filtering, which happens before a message ever reaches a sink.
+	var buf bytes.Buffer
+	l := NewWithSinks(ERROR, NewWriterSink(&buf, DEBUG, false))
+
+	l.Info("should be dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("expected INFO to be dropped at ERROR level, got %q", buf.String())
+	}
+
+	l.SetLevel(INFO)
+	if got := l.GetLevel(); got != INFO {
+		t.Fatalf("expected GetLevel() == INFO, got %d", got)
+	}
+
+	l.Info("should now be logged")
+	if !strings.Contains(buf.String(), "should now be logged") {
+		t.Fatalf("expected INFO to be logged after SetLevel, got %q", buf.String())
+	}
+}
+
+func TestSetLevelSharedWithChild(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithSinks(ERROR, NewWriterSink(&buf, DEBUG, false))
+	child := l.With(map[string]any{"a": 1})
+
+	l.SetLevel(DEBUG)
+	child.Debug("visible via shared level")
+
+	if !strings.Contains(buf.String(), "visible via shared level") {
+		t.Fatalf("expected SetLevel on parent to apply to child, got %q", buf.String())
+	}
+}
+
+func TestCloseClosesSinks(t *testing.T) {
+	var closed bool
+	l := NewWithSinks(DEBUG, closerSink{onClose: func() { closed = true }})
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error from Close: %v", err)
+	}
+	if !closed {
+		t.Fatalf("expected Close to close every sink")
+	}
+}
+
+// closerSink is a minimal Sink used to assert Logger.Close fans out to every sink.
+type closerSink struct {
+	onClose func()
+}
+
+func (s closerSink) WriteMsg(LogMessage) error { return nil }
+func (s closerSink) Close() error {
+	if s.onClose != nil {
+		s.onClose()
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPatternRedactsMatchingKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	l.InfoW("login", map[string]any{"password": "hunter2", "user": "alice"})
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+
+	if out["password"] != "*******" {
+		t.Errorf("expected password to be masked to 7 stars, got %v", out["password"])
+	}
+	if out["user"] != "alice" {
+		t.Errorf("expected unrelated field to pass through unmasked, got %v", out["user"])
+	}
+}
+
+func TestRegisterRedactorAppliesToField(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	l.RegisterRedactor(func(key, value string) string {
+		if key == "email" {
+			return "[redacted]"
+		}
+		return value
+	})
+
+	l.InfoW("signup", map[string]any{"email": "alice@example.com"})
+
+	if !strings.Contains(buf.String(), "[redacted]") {
+		t.Fatalf("expected registered redactor to mask email field, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "alice@example.com") {
+		t.Fatalf("expected raw email to not appear in output, got %q", buf.String())
+	}
+}
+
+func TestRegisterRedactorSharedWithChild(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+	child := l.With(map[string]any{"service": "api"})
+
+	l.RegisterRedactor(func(key, value string) string {
+		if key == "secret" {
+			return "[redacted]"
+		}
+		return value
+	})
+
+	child.InfoW("event", map[string]any{"secret": "abc123"})
+
+	if !strings.Contains(buf.String(), "[redacted]") {
+		t.Fatalf("expected redactor registered on parent to apply to child, got %q", buf.String())
+	}
+}
+
+type credentialValue string
+
+func (credentialValue) Redacted() any { return "[redacted-credential]" }
+
+func TestRedactorInterfaceControlsOwnMaskedForm(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	// "credential" doesn't match any default key pattern, so Redacted()'s return value
+	// reaches the output unchanged rather than being masked a second time.
+	l.InfoW("auth", map[string]any{"credential": credentialValue("abc123")})
+
+	if !strings.Contains(buf.String(), "[redacted-credential]") {
+		t.Fatalf("expected Redactor implementation to control its own masked form, got %q", buf.String())
+	}
+}
+
+func TestRedactorInterfaceResultStillMatchedByDefaultPattern(t *testing.T) {
+	// redactValue unwraps a Redactor first, then still applies the default key
+	// patterns to whatever string it returns — so a Redactor on a "token"-keyed field
+	// gets its own output masked again, per redactValue's documented ordering.
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, true)
+
+	l.InfoW("auth", map[string]any{"token": credentialValue("abc123")})
+
+	if strings.Contains(buf.String(), "[redacted-credential]") {
+		t.Fatalf("expected the Redactor's own output to be masked again since the key matches a default pattern, got %q", buf.String())
+	}
+}
+
+func TestDefaultPatternRedactsInlineMessageSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, false)
+
+	l.Info("login failed, password=hunter2")
+
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("expected an inline password=value pair in the message to be masked, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "password=*******") {
+		t.Fatalf("expected the value half to be replaced with equal-length stars, got %q", buf.String())
+	}
+}
+
+func TestMaskProducesEqualLengthStars(t *testing.T) {
+	if got := mask("hunter2"); got != "*******" {
+		t.Errorf("expected mask to return 7 stars for a 7-char input, got %q", got)
+	}
+	if got := mask(""); got != "" {
+		t.Errorf("expected mask of empty string to be empty, got %q", got)
+	}
+}
@@ -0,0 +1,388 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink represents a single log output destination with its own minimum level and
+// output format. A Logger created via NewWithSinks fans every record out to all of
+// its sinks, so different destinations can receive different levels and formats from
+// the same call site — mirroring beego/logs' pluggable adapters and op/go-logging's
+// multi-backend design.
+type Sink interface {
+	// WriteMsg writes a single log entry. Implementations are responsible for
+	// filtering by their own minimum level and rendering it in their own format;
+	// messages below the sink's level should be silently dropped, not errored.
+	WriteMsg(msg LogMessage) error
+
+	// Close releases any resources held by the sink, such as open files, network
+	// connections, or pending batches.
+	Close() error
+}
+
+// FormatJSON renders a log message as a single-line JSON object, flattening its
+// structured fields into top-level keys alongside level/msg/time. Caller info and a
+// stack trace are included when present on msg.
+func FormatJSON(msg LogMessage) (string, error) {
+	out := make(map[string]any, 6+len(msg.Fields))
+	for k, v := range msg.Fields {
+		out[k] = v
+	}
+	out["level"] = msg.Level
+	out["msg"] = msg.Msg
+	out["time"] = msg.Time
+
+	if msg.File != "" {
+		out["file"] = msg.File
+		out["line"] = msg.Line
+		out["func"] = msg.Func
+	}
+	if msg.Stack != "" {
+		out["stack"] = msg.Stack
+	}
+
+	jsonData, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling log message: %w", err)
+	}
+	return string(jsonData) + "\n", nil
+}
+
+// FormatText renders a log message as a single line of plain text: timestamp, level,
+// message, and (when present) caller info, followed by any structured fields as
+// sorted key=value pairs and a trailing stack trace block.
+func FormatText(msg LogMessage) string {
+	base := fmt.Sprintf("%s [%s] %s", // TODO: add opts for date format
+		msg.Time.Format(time.RFC3339),
+		GetLevelString(msg.Level),
+		msg.Msg,
+	)
+
+	if msg.File != "" {
+		base += fmt.Sprintf(" %s:%d %s()", msg.File, msg.Line, msg.Func)
+	}
+
+	if len(msg.Fields) > 0 {
+		keys := make([]string, 0, len(msg.Fields))
+		for k := range msg.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, msg.Fields[k]))
+		}
+
+		base += " " + strings.Join(pairs, " ")
+	}
+
+	if msg.Stack != "" {
+		base += "\n" + msg.Stack
+	}
+
+	return base + "\n"
+}
+
+// renderCache memoizes a message's rendered form per format, so that multiple sinks
+// sharing the same format trigger one render instead of one per sink.
+type renderCache struct {
+	jsonText string
+	jsonErr  error
+	jsonSet  bool
+	textText string
+	textSet  bool
+}
+
+// render returns msg formatted as JSON or text, using msg.cache if present and
+// falling back to a direct render for messages built without one (e.g. in tests).
+func (msg LogMessage) render(useJSON bool) (string, error) {
+	if msg.cache == nil {
+		if useJSON {
+			return FormatJSON(msg)
+		}
+		return FormatText(msg), nil
+	}
+
+	if useJSON {
+		if !msg.cache.jsonSet {
+			msg.cache.jsonText, msg.cache.jsonErr = FormatJSON(msg)
+			msg.cache.jsonSet = true
+		}
+		return msg.cache.jsonText, msg.cache.jsonErr
+	}
+
+	if !msg.cache.textSet {
+		msg.cache.textText = FormatText(msg)
+		msg.cache.textSet = true
+	}
+	return msg.cache.textText, nil
+}
+
+// WriterSink adapts any io.Writer into a Sink with its own minimum level and format.
+// It backs the Logger returned by New, and is the base for the other built-in sinks.
+type WriterSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+	level  int
+	json   bool
+}
+
+// NewWriterSink wraps writer as a Sink that only accepts messages at or above level,
+// formatted as JSON if json is true or plain text otherwise.
+func NewWriterSink(writer io.Writer, level int, json bool) *WriterSink {
+	return &WriterSink{writer: writer, level: level, json: json}
+}
+
+// WriteMsg formats msg according to the sink's configured format and writes it,
+// dropping messages below the sink's own minimum level.
+func (s *WriterSink) WriteMsg(msg LogMessage) error {
+	if msg.Level < s.level {
+		return nil
+	}
+
+	record, err := msg.render(s.json)
+	if err != nil {
+		return fmt.Errorf("error formatting log message: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = fmt.Fprint(s.writer, record)
+	return err
+}
+
+// Close closes the underlying writer if it implements io.Closer; otherwise it is a no-op.
+func (s *WriterSink) Close() error {
+	if closer, ok := s.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// FileSink writes log entries to a file on disk, opening it for append (creating it
+// if it doesn't exist) and closing it when the sink is closed.
+type FileSink struct {
+	*WriterSink
+	file *os.File
+}
+
+// NewFileSink opens path for append (creating it if necessary) and wraps it as a
+// FileSink accepting messages at or above level, formatted as JSON if json is true.
+func NewFileSink(path string, level int, json bool) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file %q: %w", path, err)
+	}
+	return &FileSink{WriterSink: NewWriterSink(file, level, json), file: file}, nil
+}
+
+// Close closes the underlying file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// ansiColor maps log levels to the ANSI color code ConsoleSink prefixes them with.
+var ansiColor = map[int]string{
+	DEBUG:   "\x1b[37m", // white
+	INFO:    "\x1b[36m", // cyan
+	WARNING: "\x1b[33m", // yellow
+	ERROR:   "\x1b[31m", // red
+	FATAL:   "\x1b[35m", // magenta
+}
+
+const ansiReset = "\x1b[0m"
+
+// ConsoleSink writes log entries to stdout or stderr, color-coding each line by level
+// in text mode. JSON output is never colorized, since the escape codes would corrupt
+// machine-readable output.
+type ConsoleSink struct {
+	*WriterSink
+}
+
+// NewConsoleSink wraps writer (typically os.Stdout or os.Stderr) as a ConsoleSink
+// accepting messages at or above level, formatted as JSON if json is true.
+func NewConsoleSink(writer io.Writer, level int, json bool) *ConsoleSink {
+	return &ConsoleSink{WriterSink: NewWriterSink(writer, level, json)}
+}
+
+// Close is a no-op: unlike FileSink or ConnSink, ConsoleSink doesn't own the writer
+// it's handed — it's typically os.Stdout or os.Stderr, which belong to the whole
+// process and must stay open for everything else using them. Embedding WriterSink
+// would otherwise close it via io.Closer on Logger.Close.
+func (s *ConsoleSink) Close() error {
+	return nil
+}
+
+// WriteMsg writes msg, wrapping it in the level's ANSI color when the sink is in text
+// mode and deferring to WriterSink unchanged in JSON mode.
+func (s *ConsoleSink) WriteMsg(msg LogMessage) error {
+	if s.json {
+		return s.WriterSink.WriteMsg(msg)
+	}
+	if msg.Level < s.level {
+		return nil
+	}
+
+	record, err := msg.render(false)
+	if err != nil {
+		return fmt.Errorf("error formatting log message: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = fmt.Fprint(s.writer, ansiColor[msg.Level]+strings.TrimSuffix(record, "\n")+ansiReset+"\n")
+	return err
+}
+
+// ConnSink writes log entries to a TCP connection, reconnecting the next time a
+// write fails, the same recovery strategy as beego's ConnWriter.
+type ConnSink struct {
+	mu    sync.Mutex
+	addr  string
+	level int
+	json  bool
+	conn  net.Conn
+}
+
+// NewConnSink dials addr over TCP and wraps the connection as a ConnSink accepting
+// messages at or above level, formatted as JSON if json is true.
+func NewConnSink(addr string, level int, json bool) (*ConnSink, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing log sink %q: %w", addr, err)
+	}
+	return &ConnSink{addr: addr, level: level, json: json, conn: conn}, nil
+}
+
+// WriteMsg writes msg to the connection, reconnecting once and retrying if the write
+// fails before giving up.
+func (s *ConnSink) WriteMsg(msg LogMessage) error {
+	if msg.Level < s.level {
+		return nil
+	}
+
+	record, err := msg.render(s.json)
+	if err != nil {
+		return fmt.Errorf("error formatting log message: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err = fmt.Fprint(s.conn, record); err != nil {
+		if dialErr := s.reconnect(); dialErr != nil {
+			return fmt.Errorf("error writing to conn sink (reconnect failed: %v): %w", dialErr, err)
+		}
+		_, err = fmt.Fprint(s.conn, record)
+	}
+	return err
+}
+
+// reconnect closes the current connection, if any, and dials a fresh one.
+func (s *ConnSink) reconnect() error {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	conn, err := net.Dial("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// Close closes the underlying connection.
+func (s *ConnSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Close()
+}
+
+// SMTPConfig holds the SMTP server and message details used by SMTPSink.
+type SMTPConfig struct {
+	Addr    string // host:port of the SMTP server
+	Auth    smtp.Auth
+	From    string
+	To      []string
+	Subject string
+}
+
+// SMTPSink batches ERROR and FATAL messages and emails them as a single digest
+// instead of one message per log entry, so a burst of errors doesn't flood the inbox.
+// It ignores all other levels regardless of how it's constructed.
+type SMTPSink struct {
+	mu       sync.Mutex
+	cfg      SMTPConfig
+	batch    []LogMessage
+	batchMax int
+}
+
+// NewSMTPSink creates an SMTPSink that accumulates up to batchMax ERROR/FATAL
+// messages before flushing them as a single email. Call Close to flush any
+// messages still pending at shutdown. batchMax defaults to 20 if <= 0.
+func NewSMTPSink(cfg SMTPConfig, batchMax int) *SMTPSink {
+	if batchMax <= 0 {
+		batchMax = 20
+	}
+	return &SMTPSink{cfg: cfg, batchMax: batchMax}
+}
+
+// WriteMsg appends msg to the pending batch, flushing it once batchMax is reached.
+// Messages below ERROR are ignored.
+func (s *SMTPSink) WriteMsg(msg LogMessage) error {
+	if msg.Level < ERROR {
+		return nil
+	}
+
+	s.mu.Lock()
+	s.batch = append(s.batch, msg)
+	full := len(s.batch) >= s.batchMax
+	s.mu.Unlock()
+
+	if full {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Flush sends any pending batched messages as a single email and clears the batch.
+// It satisfies the flusher interface (see logger.go), so Logger.Fatal/FatalW flush a
+// batch still under batchMax — including the very FATAL message that triggered the
+// exit — before os.Exit(1) runs.
+func (s *SMTPSink) Flush() error {
+	s.mu.Lock()
+	batch := s.batch
+	s.batch = nil
+	s.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var body strings.Builder
+	for _, msg := range batch {
+		body.WriteString(FormatText(msg))
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		s.cfg.From, strings.Join(s.cfg.To, ","), s.cfg.Subject, body.String())
+
+	return smtp.SendMail(s.cfg.Addr, s.cfg.Auth, s.cfg.From, s.cfg.To, []byte(message))
+}
+
+// Close flushes any messages still pending in the batch.
+func (s *SMTPSink) Close() error {
+	return s.Flush()
+}
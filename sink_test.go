@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatJSONFlattensFields(t *testing.T) {
+	msg := LogMessage{
+		Level:  INFO,
+		Msg:    "hello",
+		Time:   time.Now(),
+		Fields: map[string]any{"user": "alice"},
+	}
+
+	out, err := FormatJSON(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"user":"alice"`) {
+		t.Errorf("expected field to be flattened as a top-level key, got %q", out)
+	}
+	if strings.Contains(out, `"fields"`) {
+		t.Errorf("did not expect a nested \"fields\" key, got %q", out)
+	}
+}
+
+func TestFormatTextSortsFields(t *testing.T) {
+	msg := LogMessage{
+		Level: INFO,
+		Msg:   "hello",
+		Time:  time.Now(),
+		Fields: map[string]any{
+			"zebra": 1,
+			"alpha": 2,
+		},
+	}
+
+	out := FormatText(msg)
+	if strings.Index(out, "alpha=2") > strings.Index(out, "zebra=1") {
+		t.Errorf("expected fields to be rendered in sorted key order, got %q", out)
+	}
+}
+
+func TestWriterSinkFiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf, WARNING, false)
+
+	_ = sink.WriteMsg(LogMessage{Level: INFO, Msg: "dropped", Time: time.Now()})
+	if buf.Len() != 0 {
+		t.Fatalf("expected message below sink level to be dropped, got %q", buf.String())
+	}
+
+	_ = sink.WriteMsg(LogMessage{Level: WARNING, Msg: "kept", Time: time.Now()})
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("expected message at sink level to be written, got %q", buf.String())
+	}
+}
+
+func TestFileSinkWritesAndCloses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+
+	sink, err := NewFileSink(path, DEBUG, false)
+	if err != nil {
+		t.Fatalf("unexpected error creating FileSink: %v", err)
+	}
+
+	if err := sink.WriteMsg(LogMessage{Level: INFO, Msg: "to disk", Time: time.Now()}); err != nil {
+		t.Fatalf("unexpected error writing to FileSink: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error closing FileSink: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading log file: %v", err)
+	}
+	if !strings.Contains(string(data), "to disk") {
+		t.Errorf("expected file to contain the written message, got %q", data)
+	}
+}
+
+func TestConsoleSinkColorizesTextNotJSON(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewConsoleSink(&buf, DEBUG, false)
+	_ = sink.WriteMsg(LogMessage{Level: ERROR, Msg: "boom", Time: time.Now()})
+	if !strings.Contains(buf.String(), ansiColor[ERROR]) {
+		t.Errorf("expected text-mode ConsoleSink output to be colorized, got %q", buf.String())
+	}
+
+	buf.Reset()
+	jsonSink := NewConsoleSink(&buf, DEBUG, true)
+	_ = jsonSink.WriteMsg(LogMessage{Level: ERROR, Msg: "boom", Time: time.Now()})
+	if strings.Contains(buf.String(), ansiColor[ERROR]) {
+		t.Errorf("did not expect JSON-mode ConsoleSink output to be colorized, got %q", buf.String())
+	}
+}
+
+func TestSMTPSinkBatchesBelowThreshold(t *testing.T) {
+	sink := NewSMTPSink(SMTPConfig{Addr: "smtp.invalid:25", From: "a@example.com", To: []string{"b@example.com"}}, 5)
+
+	for i := 0; i < 3; i++ {
+		if err := sink.WriteMsg(LogMessage{Level: ERROR, Msg: "boom", Time: time.Now()}); err != nil {
+			t.Fatalf("unexpected error batching message %d: %v", i, err)
+		}
+	}
+
+	if len(sink.batch) != 3 {
+		t.Errorf("expected 3 messages held in the batch below batchMax, got %d", len(sink.batch))
+	}
+}
+
+func TestSMTPSinkIgnoresBelowError(t *testing.T) {
+	sink := NewSMTPSink(SMTPConfig{Addr: "smtp.invalid:25"}, 5)
+
+	_ = sink.WriteMsg(LogMessage{Level: INFO, Msg: "not an error", Time: time.Now()})
+	if len(sink.batch) != 0 {
+		t.Errorf("expected INFO messages to be ignored by SMTPSink, got batch %v", sink.batch)
+	}
+}
+
+func TestSMTPSinkSatisfiesFlusher(t *testing.T) {
+	// Regression test: SMTPSink must export Flush so Logger.Fatal's flushSinks call
+	// (not just Close) can drain a batch still under batchMax before os.Exit.
+	var _ flusher = (*SMTPSink)(nil)
+
+	sink := NewSMTPSink(SMTPConfig{Addr: "smtp.invalid:25"}, 5)
+	_ = sink.WriteMsg(LogMessage{Level: ERROR, Msg: "boom", Time: time.Now()})
+	if len(sink.batch) != 1 {
+		t.Fatalf("expected 1 message held below batchMax, got %d", len(sink.batch))
+	}
+
+	// Flush() itself will try to send mail and fail against an invalid address, but it
+	// must still have cleared the batch, proving it ran rather than no-op'd.
+	_ = sink.Flush()
+	if len(sink.batch) != 0 {
+		t.Errorf("expected Flush to clear the pending batch, got %v", sink.batch)
+	}
+}
+
+func TestConsoleSinkCloseDoesNotCloseWriter(t *testing.T) {
+	// Regression test: ConsoleSink must not close process-owned writers like
+	// os.Stdout/os.Stderr via the embedded WriterSink's io.Closer passthrough.
+	f, err := os.CreateTemp(t.TempDir(), "console-sink-*")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp file: %v", err)
+	}
+	defer f.Close()
+
+	sink := NewConsoleSink(f, DEBUG, false)
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error from ConsoleSink.Close: %v", err)
+	}
+
+	if _, err := f.WriteString("still open"); err != nil {
+		t.Errorf("expected the underlying writer to remain open after ConsoleSink.Close, got %v", err)
+	}
+}
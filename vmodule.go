@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// vmoduleCallerSkip is how many frames vmoduleConfig.resolve must ascend from inside
+// itself to reach the caller of Logger.V: resolve -> V -> the user. That's 2 frames
+// above resolve's own, per runtime.Caller's "0 is the caller of Caller" convention.
+const vmoduleCallerSkip = 2
+
+// vmoduleConfig holds per-source-file verbosity overrides parsed from a vmodule
+// string like "cache=2,http=3", resolved against the base name (without extension)
+// of the file that calls V, and cached by program counter so repeated calls from the
+// same call site stay O(1) after the first lookup.
+type vmoduleConfig struct {
+	patterns map[string]int // file base name (no extension) -> verbosity
+
+	mu    sync.RWMutex
+	cache map[uintptr]int // caller PC -> resolved verbosity, or -1 for "no override"
+}
+
+// parseVmodule parses a comma-separated list of "pattern=level" entries, e.g.
+// "cache=2,http=3". Malformed entries are skipped rather than returned as an error,
+// matching klog's permissive --vmodule parsing.
+func parseVmodule(vmodule string) *vmoduleConfig {
+	cfg := &vmoduleConfig{
+		patterns: make(map[string]int),
+		cache:    make(map[uintptr]int),
+	}
+
+	for _, entry := range strings.Split(vmodule, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, levelStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+
+		level, err := strconv.Atoi(strings.TrimSpace(levelStr))
+		if err != nil {
+			continue
+		}
+
+		cfg.patterns[strings.TrimSpace(name)] = level
+	}
+
+	return cfg
+}
+
+// resolve returns the verbosity override configured for the file skip frames above
+// it, or ok=false if no pattern matches that file.
+func (c *vmoduleConfig) resolve(skip int) (level int, ok bool) {
+	pc, file, _, caught := runtime.Caller(skip)
+	if !caught {
+		return 0, false
+	}
+
+	c.mu.RLock()
+	cached, hit := c.cache[pc]
+	c.mu.RUnlock()
+	if hit {
+		return cached, cached >= 0
+	}
+
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+	level, matched := c.patterns[base]
+	resolved := level
+	if !matched {
+		resolved = -1
+	}
+
+	c.mu.Lock()
+	c.cache[pc] = resolved
+	c.mu.Unlock()
+
+	return level, matched
+}
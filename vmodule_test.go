@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseVmoduleParsesPairs(t *testing.T) {
+	cfg := parseVmodule("cache=2, http=3,malformed,empty=")
+
+	if level, ok := cfg.patterns["cache"]; !ok || level != 2 {
+		t.Errorf("expected cache=2 to parse, got %v, ok=%v", level, ok)
+	}
+	if level, ok := cfg.patterns["http"]; !ok || level != 3 {
+		t.Errorf("expected http=3 to parse with surrounding space trimmed, got %v, ok=%v", level, ok)
+	}
+	if _, ok := cfg.patterns["malformed"]; ok {
+		t.Errorf("expected an entry with no '=' to be skipped")
+	}
+	if _, ok := cfg.patterns["empty"]; ok {
+		t.Errorf("expected an entry with a non-numeric level to be skipped")
+	}
+}
+
+func TestSetVerbosityGatesV(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, false)
+
+	if l.V(2).Enabled() {
+		t.Fatalf("expected V(2) to be disabled at the default verbosity of 0")
+	}
+
+	l.SetVerbosity(2)
+	if !l.V(2).Enabled() {
+		t.Fatalf("expected V(2) to be enabled after SetVerbosity(2)")
+	}
+	if l.GetVerbosity() != 2 {
+		t.Fatalf("expected GetVerbosity() == 2, got %d", l.GetVerbosity())
+	}
+
+	l.V(2).Info("traced")
+	if !strings.Contains(buf.String(), "traced") {
+		t.Fatalf("expected V(2).Info to log once enabled, got %q", buf.String())
+	}
+}
+
+func TestVDisabledSkipsLogging(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(DEBUG, &buf, false)
+
+	l.V(5).Info("should not appear")
+	if buf.Len() != 0 {
+		t.Fatalf("expected V(5).Info to no-op when verbosity is 0, got %q", buf.String())
+	}
+}